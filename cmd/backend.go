@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/wreulicke/selectag/pkg/selectag"
+)
+
+var (
+	backendFlag        string
+	releaseBackendFlag string
+
+	gitBackendOpt     selectag.Option
+	releaseBackendOpt selectag.Option
+)
+
+// resolveBackends turns --backend and --release-backend into the
+// selectag.Option values selectagOptions appends to every library call. It
+// must be called once at the start of each command's RunE, before any
+// selectag function is invoked.
+func resolveBackends() error {
+	switch backendFlag {
+	case "", "cli":
+		gitBackendOpt = selectag.WithGitBackend(selectag.NewCLIGitBackend("."))
+	case "go-git":
+		backend, err := selectag.NewGoGitBackend(".")
+		if err != nil {
+			return fmt.Errorf("failed to initialize go-git backend: %w", err)
+		}
+		gitBackendOpt = selectag.WithGitBackend(backend)
+	default:
+		return fmt.Errorf("invalid --backend value %q: must be \"cli\" or \"go-git\"", backendFlag)
+	}
+
+	switch releaseBackendFlag {
+	case "", "gh":
+		releaseBackendOpt = selectag.WithReleaseBackend(selectag.NewGHReleaseBackend())
+	case "go-github":
+		owner, repo, err := parseGitHubSlug(os.Getenv("GITHUB_REPOSITORY"))
+		if err != nil {
+			return fmt.Errorf("--release-backend=go-github requires GITHUB_REPOSITORY=owner/repo: %w", err)
+		}
+		token := os.Getenv("GITHUB_TOKEN")
+		if token == "" {
+			token = os.Getenv("GH_TOKEN")
+		}
+		releaseBackendOpt = selectag.WithReleaseBackend(selectag.NewGoGithubReleaseBackend(owner, repo, token))
+	case "none":
+		releaseBackendOpt = selectag.WithReleaseBackend(selectag.NewNoopReleaseBackend())
+	default:
+		return fmt.Errorf("invalid --release-backend value %q: must be \"gh\", \"go-github\", or \"none\"", releaseBackendFlag)
+	}
+
+	return nil
+}
+
+func parseGitHubSlug(slug string) (owner, repo string, err error) {
+	owner, repo, ok := strings.Cut(slug, "/")
+	if !ok || owner == "" || repo == "" {
+		return "", "", fmt.Errorf("expected \"owner/repo\", got %q", slug)
+	}
+	return owner, repo, nil
+}
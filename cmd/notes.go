@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+	"github.com/wreulicke/selectag/pkg/selectag"
+)
+
+var notesTemplate string
+
+// NewNotesCommand builds the `notes` subcommand, which prints a categorized
+// changelog for a module instead of delegating to `gh release create
+// --generate-notes`.
+func NewNotesCommand() *cobra.Command {
+	var fromRef, toRef string
+
+	cmd := &cobra.Command{
+		Use:   "notes",
+		Short: "Print a categorized changelog between two tags",
+		Long:  `Compose release notes from conventional-commit messages, grouped into Breaking Changes, Features, Bug Fixes, Performance, Refactoring, Documentation, and Other sections.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runNotes(cmd, fromRef, toRef)
+		},
+	}
+	cmd.Flags().StringVarP(&prefix, "prefix", "p", "", "Module prefix to scope commits and issue-reference extraction to")
+	cmd.Flags().StringVar(&fromRef, "from", "", "Tag to start the changelog from (defaults to the module's current tag)")
+	cmd.Flags().StringVar(&toRef, "to", "", "Tag or ref to end the changelog at (defaults to origin/<default branch>)")
+	cmd.Flags().StringVar(&notesTemplate, "template", "", "Path to a Go text/template used to render the notes instead of the built-in markdown format")
+
+	return cmd
+}
+
+func runNotes(cmd *cobra.Command, fromRef, toRef string) error {
+	if err := resolveBackends(); err != nil {
+		return err
+	}
+
+	prefixes, err := resolvePrefixes()
+	if err != nil {
+		return err
+	}
+	if len(prefixes) != 1 {
+		return fmt.Errorf("notes requires exactly one module prefix; use --prefix to select one (found %d)", len(prefixes))
+	}
+	selectedPrefix := prefixes[0]
+
+	if fromRef == "" {
+		current, err := selectag.CurrentVersion(selectedPrefix, selectagOptions()...)
+		if err != nil {
+			return fmt.Errorf("failed to get current version: %w", err)
+		}
+		fromRef = selectag.GitTag(selectedPrefix, current)
+	}
+	if toRef == "" {
+		toRef = "origin/" + defaultBranch
+	}
+
+	rendered, err := renderNotes(selectedPrefix, fromRef, toRef)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), rendered)
+	return nil
+}
+
+// renderNotes composes the release notes for prefix between fromRef and
+// toRef, rendering them with notesTemplate when it is set, or with
+// ReleaseNotes.Markdown otherwise.
+func renderNotes(prefix, fromRef, toRef string) (string, error) {
+	notes, err := selectag.ComposeNotes(fromRef, toRef, prefix, selectagOptions()...)
+	if err != nil {
+		return "", fmt.Errorf("failed to compose release notes: %w", err)
+	}
+
+	if notesTemplate == "" {
+		return notes.Markdown(), nil
+	}
+
+	content, err := os.ReadFile(notesTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to read notes template %s: %w", notesTemplate, err)
+	}
+	tmpl, err := template.New(filepath.Base(notesTemplate)).Parse(string(content))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse notes template %s: %w", notesTemplate, err)
+	}
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, notes); err != nil {
+		return "", fmt.Errorf("failed to render notes template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// writeReleaseNotesFile composes and renders the release notes for prefix
+// between oldTag and newTag, writing them to a temp file so callers can
+// pass it to `gh release create --notes-file`. The returned cleanup func
+// removes the temp file and should be deferred by the caller.
+func writeReleaseNotesFile(prefix, oldTag, newTag string) (path string, cleanup func(), err error) {
+	rendered, err := renderNotes(prefix, oldTag, newTag)
+	if err != nil {
+		return "", func() {}, err
+	}
+
+	f, err := os.CreateTemp("", "selectag-notes-*.md")
+	if err != nil {
+		return "", func() {}, fmt.Errorf("failed to create release notes file: %w", err)
+	}
+	cleanup = func() { os.Remove(f.Name()) }
+
+	if _, err := f.WriteString(rendered); err != nil {
+		f.Close()
+		cleanup()
+		return "", func() {}, fmt.Errorf("failed to write release notes file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		cleanup()
+		return "", func() {}, fmt.Errorf("failed to write release notes file: %w", err)
+	}
+
+	return f.Name(), cleanup, nil
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/spf13/cobra"
+	"github.com/wreulicke/selectag/pkg/selectag"
+)
+
+// NewReleaseAllCommand builds the `release-all` subcommand: it discovers
+// every module with unreleased commits, lets the user multi-select which to
+// release together, and tags/pushes/releases them in dependency order.
+func NewReleaseAllCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "release-all",
+		Short: "Release multiple modules together in one batch",
+		Long:  `Discovers every module prefix with unreleased commits and walks you through a multi-select to tag, push, and create GitHub releases for several of them at once, in dependency order.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := resolveBackends(); err != nil {
+				return err
+			}
+			return runReleaseAll(cmd, false)
+		},
+	}
+}
+
+type plannedRelease struct {
+	prefix     string
+	newVersion string
+	oldTag     string
+	newTag     string
+}
+
+// runReleaseAll drives the multi-module release flow. When nonInteractive is
+// true (set via the root --all flag), every module with unreleased commits
+// is released automatically instead of through a multi-select prompt.
+func runReleaseAll(cmd *cobra.Command, nonInteractive bool) error {
+	prefixes, err := selectag.Prefixes(selectagOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to collect git tag prefixes: %w", err)
+	}
+	if len(prefixes) == 0 {
+		return fmt.Errorf("no tag prefixes found. Create git tags first (e.g., git tag v1.0.0)")
+	}
+
+	results, err := selectag.Verify(prefixes, selectagOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to check modules for unreleased changes: %w", err)
+	}
+
+	var candidates []string
+	for _, r := range results {
+		if r.NumChanges > 0 {
+			candidates = append(candidates, r.Prefix)
+		}
+	}
+	slices.Sort(candidates)
+
+	if len(candidates) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No modules have unreleased changes.")
+		return nil
+	}
+
+	selected := candidates
+	if !nonInteractive {
+		var options []huh.Option[string]
+		for _, p := range candidates {
+			label := p
+			if label == "" {
+				label = "(root)"
+			}
+			options = append(options, huh.NewOption(label, p))
+		}
+
+		selected = nil
+		err := huh.NewForm(
+			huh.NewGroup(
+				huh.NewMultiSelect[string]().
+					Title("Select modules to release together").
+					Description("Modules with unreleased commits").
+					Options(options...).
+					Value(&selected),
+			),
+		).Run()
+		if err != nil {
+			return fmt.Errorf("form error: %w", err)
+		}
+		if len(selected) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "No modules selected; nothing to release.")
+			return nil
+		}
+	}
+
+	ordered, err := selectag.OrderByDependency(selected, selectagOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to order modules by dependency: %w", err)
+	}
+
+	var plan []plannedRelease
+	for _, p := range ordered {
+		bump, err := selectag.SuggestBump(p, selectagOptions()...)
+		if err != nil {
+			return fmt.Errorf("failed to suggest a version for %q: %w", p, err)
+		}
+		if bump == selectag.BumpNone {
+			bump = selectag.BumpPatch
+		}
+
+		oldVersion, err := selectag.CurrentVersion(p, selectagOptions()...)
+		if err != nil {
+			return fmt.Errorf("failed to get current version for %q: %w", p, err)
+		}
+		newVersion, err := selectag.NextVersion(p, bump, selectagOptions()...)
+		if err != nil {
+			return fmt.Errorf("failed to compute next version for %q: %w", p, err)
+		}
+
+		plan = append(plan, plannedRelease{
+			prefix:     p,
+			newVersion: newVersion,
+			oldTag:     selectag.GitTag(p, oldVersion),
+			newTag:     selectag.GitTag(p, newVersion),
+		})
+	}
+
+	for _, r := range plan {
+		_, err := selectag.CreateTag(r.prefix, r.newVersion, fmt.Sprintf("release %s", r.newTag), "origin/"+defaultBranch, selectagOptions()...)
+		if err != nil {
+			return fmt.Errorf("failed to create git tag %s: %w", r.newTag, err)
+		}
+		log.Println("Created git tag:", r.newTag)
+	}
+
+	if !nonInteractive && !continued("Do you want to push all tags and create GitHub releases now?") {
+		return nil
+	}
+
+	for _, r := range plan {
+		if err := selectag.PushTag("origin", r.newTag, selectagOptions()...); err != nil {
+			return fmt.Errorf("failed to push tag %s: %w", r.newTag, err)
+		}
+	}
+	log.Println("Pushed tags to origin:", strings.Join(tagNames(plan), ", "))
+
+	for _, r := range plan {
+		notesPath, cleanupNotes, err := writeReleaseNotesFile(r.prefix, r.oldTag, r.newTag)
+		if err != nil {
+			return fmt.Errorf("failed to compose release notes for %s: %w", r.newTag, err)
+		}
+
+		err = selectag.CreateRelease(r.newTag, notesPath, true, selectagOptions()...)
+		cleanupNotes()
+		if err != nil {
+			return fmt.Errorf("failed to create GitHub release for %s: %w", r.newTag, err)
+		}
+		log.Println("Created GitHub release for tag:", r.newTag)
+	}
+
+	return nil
+}
+
+func tagNames(plan []plannedRelease) []string {
+	names := make([]string, len(plan))
+	for i, r := range plan {
+		names[i] = r.newTag
+	}
+	return names
+}
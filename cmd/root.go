@@ -2,22 +2,22 @@ package cmd
 
 import (
 	"fmt"
-	"io"
 	"log"
-	"maps"
 	"os/exec"
-	"regexp"
-	"slices"
 	"strings"
 
 	"github.com/charmbracelet/huh"
-	version "github.com/hashicorp/go-version"
 	"github.com/spf13/cobra"
+	"github.com/wreulicke/selectag/pkg/selectag"
 )
 
 var (
-	prefix        string
-	defaultBranch string
+	prefix         string
+	defaultBranch  string
+	bumpFlag       string
+	allFlag        bool
+	preReleaseFlag string
+	buildFlag      string
 )
 
 func NewRootCommand() *cobra.Command {
@@ -37,28 +37,66 @@ func NewRootCommand() *cobra.Command {
 		RunE:  runSelectTag,
 	}
 	cmd.Flags().StringVarP(&prefix, "prefix", "p", "", "Add additional tag prefix options (can be used multiple times)")
+	cmd.Flags().StringVar(&bumpFlag, "bump", "", "Skip the interactive prompt and tag non-interactively: auto|patch|minor|major|release (auto inspects conventional commits, release promotes the current prerelease; requires --prefix)")
+	cmd.Flags().BoolVar(&allFlag, "all", false, "Non-interactively release every module with unreleased commits (see also: release-all)")
+	cmd.Flags().StringVar(&preReleaseFlag, "pre-release", "", "Prerelease label to apply with --bump, e.g. rc or beta (bumps the existing prerelease if it already uses this label)")
+	cmd.Flags().StringVar(&buildFlag, "build", "", "Build metadata to append to the new version, e.g. build.5")
+	cmd.Flags().StringVar(&notesTemplate, "notes-template", "", "Path to a Go text/template used to render GitHub release notes (see also: notes --template)")
+	cmd.PersistentFlags().StringVar(&backendFlag, "backend", "cli", "Git backend to use: cli|go-git")
+	cmd.PersistentFlags().StringVar(&releaseBackendFlag, "release-backend", "gh", "GitHub release backend to use: gh|go-github|none")
 
 	cmd.AddCommand(NewVerifyCommand())
+	cmd.AddCommand(NewReleaseAllCommand())
+	cmd.AddCommand(NewNotesCommand())
 	return cmd
 }
 
-func runSelectTag(cmd *cobra.Command, args []string) error {
+// selectagOptions builds the selectag.Option set that reflects the flags and
+// detected state shared across subcommands. resolveBackends must have been
+// called already.
+func selectagOptions() []selectag.Option {
+	opts := []selectag.Option{selectag.WithDefaultBranch(defaultBranch)}
+	if gitBackendOpt != nil {
+		opts = append(opts, gitBackendOpt)
+	}
+	if releaseBackendOpt != nil {
+		opts = append(opts, releaseBackendOpt)
+	}
+	return opts
+}
 
-	// Collect tag prefixes from git tags
-	var prefixes []string
-	var err error
+// resolvePrefixes turns the --prefix flag (or its absence) into the set of
+// module prefixes a command should operate on.
+func resolvePrefixes() ([]string, error) {
 	if prefix == "root" {
-		prefixes = []string{""}
-	} else if prefix != "" {
-		prefixes = []string{strings.TrimSpace(prefix)}
-	} else {
-		prefixes, err = collectTagPrefixesFromGit()
-		if err != nil {
-			return fmt.Errorf("failed to collect git tag prefixes: %w", err)
-		}
+		return []string{""}, nil
+	}
+	if prefix != "" {
+		return []string{strings.TrimSpace(prefix)}, nil
 	}
+	prefixes, err := selectag.Prefixes(selectagOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect git tag prefixes: %w", err)
+	}
+	return prefixes, nil
+}
 
-	// Convert map back to slice
+func runSelectTag(cmd *cobra.Command, args []string) error {
+	if err := resolveBackends(); err != nil {
+		return err
+	}
+
+	if allFlag {
+		return runReleaseAll(cmd, true)
+	}
+	if bumpFlag != "" {
+		return runSelectTagNonInteractive(cmd)
+	}
+
+	prefixes, err := resolvePrefixes()
+	if err != nil {
+		return err
+	}
 	if len(prefixes) == 0 {
 		return fmt.Errorf("no tag prefixes found. Either create git tags (e.g., git tag v1.0.0) or use --prefix flag")
 	}
@@ -83,28 +121,69 @@ func runSelectTag(cmd *cobra.Command, args []string) error {
 	var releaseTitle string
 
 	generateNewVersionOptions := func() []huh.Option[string] {
-		currentVersion, err := getCurrentVersion(selectedPrefix)
+		currentVersion, err := selectag.CurrentVersion(selectedPrefix, selectagOptions()...)
 		if err != nil {
 			panic(fmt.Sprintf("failed to get current version: %v", err))
 		}
-		v, err := version.NewSemver(currentVersion)
+
+		patch, err := selectag.NextVersion(selectedPrefix, selectag.BumpPatch, selectagOptions()...)
 		if err != nil {
-			panic(fmt.Sprintf("failed to parse current version: %v", err))
+			panic(fmt.Sprintf("failed to compute next version: %v", err))
+		}
+		minor, err := selectag.NextVersion(selectedPrefix, selectag.BumpMinor, selectagOptions()...)
+		if err != nil {
+			panic(fmt.Sprintf("failed to compute next version: %v", err))
+		}
+		major, err := selectag.NextVersion(selectedPrefix, selectag.BumpMajor, selectagOptions()...)
+		if err != nil {
+			panic(fmt.Sprintf("failed to compute next version: %v", err))
 		}
-		segments := v.Segments()
 
-		major := fmt.Sprintf("%d.0.0", segments[0]+1)
-		minor := fmt.Sprintf("%d.%d.0", segments[0], segments[1]+1)
-		patch := fmt.Sprintf("%d.%d.%d", segments[0], segments[1], segments[2]+1)
 		suggestions := []huh.Option[string]{
 			huh.NewOption(fmt.Sprintf("patch - %s", patch), patch),
 			huh.NewOption(fmt.Sprintf("minor - %s", minor), minor),
 			huh.NewOption(fmt.Sprintf("major - %s", major), major),
 		}
-		if len(v.Prerelease()) > 0 {
-			// also suggest removing prerelease
-			cleanVersion := fmt.Sprintf("%d.%d.%d", segments[0], segments[1], segments[2])
-			suggestions = append([]huh.Option[string]{huh.NewOption(fmt.Sprintf("remove prerelease - %s", cleanVersion), cleanVersion)}, suggestions...)
+
+		recommended, err := selectag.SuggestBump(selectedPrefix, selectagOptions()...)
+		if err != nil {
+			panic(fmt.Sprintf("failed to inspect commits for a recommended bump: %v", err))
+		}
+		if recommended != selectag.BumpNone {
+			recommendedVersion, err := selectag.NextVersion(selectedPrefix, recommended, selectagOptions()...)
+			if err != nil {
+				panic(fmt.Sprintf("failed to compute next version: %v", err))
+			}
+			recommendedOption := huh.NewOption(fmt.Sprintf("recommended: %s - %s", recommended, recommendedVersion), recommendedVersion).Selected(true)
+			suggestions = append([]huh.Option[string]{recommendedOption}, suggestions...)
+		}
+
+		if strings.Contains(currentVersion, "-") {
+			// currentVersion already is a prerelease: offer to bump it or
+			// promote it to a full release instead of computing a new one.
+			bumped, err := selectag.BumpPrerelease(selectedPrefix, selectagOptions()...)
+			if err != nil {
+				panic(fmt.Sprintf("failed to compute next prerelease version: %v", err))
+			}
+			suggestions = append([]huh.Option[string]{huh.NewOption(fmt.Sprintf("bump prerelease - %s", bumped), bumped)}, suggestions...)
+
+			promoted, err := selectag.PromoteToRelease(selectedPrefix, selectagOptions()...)
+			if err != nil {
+				panic(fmt.Sprintf("failed to compute promoted release version: %v", err))
+			}
+			suggestions = append([]huh.Option[string]{huh.NewOption(fmt.Sprintf("promote to release - %s", promoted), promoted)}, suggestions...)
+		} else {
+			preBump := recommended
+			if preBump == selectag.BumpNone {
+				preBump = selectag.BumpPatch
+			}
+			for _, label := range []string{"rc", "beta"} {
+				pre, err := selectag.NextPrereleaseVersion(selectedPrefix, preBump, label, selectagOptions()...)
+				if err != nil {
+					panic(fmt.Sprintf("failed to compute next prerelease version: %v", err))
+				}
+				suggestions = append(suggestions, huh.NewOption(fmt.Sprintf("prerelease - %s", pre), pre))
+			}
 		}
 		return suggestions
 	}
@@ -124,7 +203,7 @@ func runSelectTag(cmd *cobra.Command, args []string) error {
 				Description("Choose new version").
 				Value(&newVersion).
 				OptionsFunc(generateNewVersionOptions, &selectedPrefix).
-				Validate(validateVersion),
+				Validate(selectag.ValidateVersion),
 		),
 		huh.NewGroup(
 			huh.NewInput().
@@ -140,15 +219,21 @@ func runSelectTag(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("form error: %w", err)
 	}
 
+	if buildFlag != "" {
+		newVersion = fmt.Sprintf("%s+%s", newVersion, buildFlag)
+		if err := selectag.ValidateVersion(newVersion); err != nil {
+			return fmt.Errorf("version %q with --build applied is invalid: %w", newVersion, err)
+		}
+	}
+
 	// Generate the full tag
-	oldVersion, err := getCurrentVersion(selectedPrefix)
+	oldVersion, err := selectag.CurrentVersion(selectedPrefix, selectagOptions()...)
 	if err != nil {
 		return fmt.Errorf("failed to get current version: %w", err)
 	}
-	newTag := getGitTagFromVersion(selectedPrefix, newVersion)
-	oldTag := getGitTagFromVersion(selectedPrefix, oldVersion)
+	oldTag := selectag.GitTag(selectedPrefix, oldVersion)
 
-	err = execCmd(cmd.OutOrStdout(), cmd.OutOrStderr(), "git", "tag", newTag, "-a", "-m", releaseTitle, "origin/"+defaultBranch)
+	newTag, err := selectag.CreateTag(selectedPrefix, newVersion, releaseTitle, "origin/"+defaultBranch, selectagOptions()...)
 	if err != nil {
 		return fmt.Errorf("failed to create git tag: %w", err)
 	}
@@ -158,8 +243,7 @@ func runSelectTag(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	err = execCmd(cmd.OutOrStdout(), cmd.OutOrStderr(), "git", "push", "origin", newTag)
-	if err != nil {
+	if err := selectag.PushTag("origin", newTag, selectagOptions()...); err != nil {
 		return fmt.Errorf("failed to push git tag: %w", err)
 	}
 	log.Println("Pushed git tag to origin:", newTag)
@@ -168,124 +252,98 @@ func runSelectTag(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	err = execCmd(cmd.OutOrStdout(), cmd.OutOrStderr(), "gh", "release", "create", newTag, "--draft", "--generate-notes", "--notes-start-tag", oldTag, "--fail-on-no-commits")
+	notesPath, cleanupNotes, err := writeReleaseNotesFile(selectedPrefix, oldTag, newTag)
 	if err != nil {
-		return fmt.Errorf("failed to create GitHub release: %w", err)
-	}
-	log.Println("Created GitHub release for tag:", newTag)
-
-	return nil
-}
-
-// validateVersion checks if the version string is valid using go-version
-func validateVersion(s string) error {
-	if s == "" {
-		return fmt.Errorf("version cannot be empty")
-	}
-
-	// Add 'v' prefix if not present for validation
-	versionStr := s
-	if !strings.HasPrefix(versionStr, "v") {
-		versionStr = "v" + versionStr
+		return fmt.Errorf("failed to compose release notes: %w", err)
 	}
+	defer cleanupNotes()
 
-	// Parse and validate using go-version
-	_, err := version.NewSemver(versionStr)
-	if err != nil {
-		return fmt.Errorf("invalid version format: %w", err)
+	if err := selectag.CreateRelease(newTag, notesPath, true, selectagOptions()...); err != nil {
+		return fmt.Errorf("failed to create GitHub release: %w", err)
 	}
+	log.Println("Created GitHub release for tag:", newTag)
 
 	return nil
 }
 
-func getCurrentVersion(prefix string) (string, error) {
-	if prefix == "" {
-		prefix = "v"
-	} else {
-		prefix = prefix + "/v"
-	}
-
-	cmd := exec.Command("git", "tag", "--list", fmt.Sprintf("%s**", prefix), "--sort=-v:refname")
-	output, err := cmd.Output()
+// runSelectTagNonInteractive handles --bump=auto|patch|minor|major|release, creating
+// the computed tag directly so selectag can run unattended in CI.
+func runSelectTagNonInteractive(cmd *cobra.Command) error {
+	prefixes, err := resolvePrefixes()
 	if err != nil {
-		return "", fmt.Errorf("failed to list git tags: %w", err)
+		return err
 	}
-
-	tags := strings.Split(strings.TrimSpace(string(output)), "\n")
-	if len(tags) == 0 {
-		return "", fmt.Errorf("no tags found with prefix %s", prefix)
+	if len(prefixes) != 1 {
+		return fmt.Errorf("--bump requires exactly one module prefix; use --prefix to select one (found %d)", len(prefixes))
 	}
+	selectedPrefix := prefixes[0]
 
-	slices.SortFunc(tags, func(i, j string) int {
-		vi, err1 := version.NewSemver(strings.TrimPrefix(i, prefix))
-		vj, err2 := version.NewSemver(strings.TrimPrefix(j, prefix))
-		if err1 != nil || err2 != nil {
-			return 0
+	var newVersion string
+	var releaseKind string
+	if bumpFlag == "release" {
+		if preReleaseFlag != "" {
+			return fmt.Errorf("--pre-release cannot be combined with --bump=release")
 		}
-		if vi.GreaterThan(vj) {
-			return -1
+		promoted, err := selectag.PromoteToRelease(selectedPrefix, selectagOptions()...)
+		if err != nil {
+			return fmt.Errorf("failed to promote prerelease to release: %w", err)
 		}
-		return 1
-	})
-	return strings.TrimPrefix(strings.TrimSpace(tags[0]), prefix), nil
-}
+		newVersion = promoted
+		releaseKind = "release"
+	} else {
+		var bump selectag.Bump
+		switch bumpFlag {
+		case "auto":
+			suggested, err := selectag.SuggestBump(selectedPrefix, selectagOptions()...)
+			if err != nil {
+				return fmt.Errorf("failed to determine recommended bump: %w", err)
+			}
+			if suggested == selectag.BumpNone {
+				return fmt.Errorf("no conventional-commit changes found to release for prefix %q", selectedPrefix)
+			}
+			bump = suggested
+		case "patch":
+			bump = selectag.BumpPatch
+		case "minor":
+			bump = selectag.BumpMinor
+		case "major":
+			bump = selectag.BumpMajor
+		default:
+			return fmt.Errorf("invalid --bump value %q: must be one of auto, patch, minor, major, release", bumpFlag)
+		}
+		releaseKind = string(bump)
 
-func getGitTagFromVersion(prefix, version string) string {
-	if prefix == "" {
-		return "v" + version
+		if preReleaseFlag != "" {
+			pre, err := selectag.NextPrereleaseVersion(selectedPrefix, bump, preReleaseFlag, selectagOptions()...)
+			if err != nil {
+				return fmt.Errorf("failed to compute next prerelease version: %w", err)
+			}
+			newVersion = pre
+			releaseKind = fmt.Sprintf("%s %s", bump, preReleaseFlag)
+		} else {
+			next, err := selectag.NextVersion(selectedPrefix, bump, selectagOptions()...)
+			if err != nil {
+				return fmt.Errorf("failed to compute next version: %w", err)
+			}
+			newVersion = next
+		}
 	}
-	return prefix + "/v" + version
-}
 
-// collectTagPrefixesFromGit collects unique tag prefixes from existing git tags
-func collectTagPrefixesFromGit() ([]string, error) {
-	// Run git tag -l to list all tags
-	cmd := exec.Command("git", "tag", "-l")
-	output, err := cmd.Output()
-	if err != nil {
-		// If git command fails, it might not be a git repo or no tags exist
-		// Return empty slice instead of error to allow fallback to go.mod detection
-		return []string{}, nil
+	if buildFlag != "" {
+		newVersion = fmt.Sprintf("%s+%s", newVersion, buildFlag)
 	}
-
-	tags := strings.Split(strings.TrimSpace(string(output)), "\n")
-	if len(tags) == 1 && tags[0] == "" {
-		// No tags found
-		return []string{}, nil
+	if err := selectag.ValidateVersion(newVersion); err != nil {
+		return fmt.Errorf("computed version %q is invalid: %w", newVersion, err)
 	}
 
-	// Regex pattern to match version suffix: /v followed by digits
-	// This captures everything before the version as the prefix
-	versionPattern := regexp.MustCompile(`^((.*)/v\d+|v\d+)`)
-
-	prefixMap := make(map[string]struct{})
-
-	for _, tag := range tags {
-		tag = strings.TrimSpace(tag)
-		if tag == "" {
-			continue
-		}
-
-		// Try to extract prefix using regex
-		matches := versionPattern.FindStringSubmatch(tag)
-		if len(matches) > 2 {
-			prefix := matches[2]
-			prefixMap[prefix] = struct{}{}
-		} else if len(matches) > 1 {
-			// Tag is like v1.0.0 with no prefix
-			prefixMap[""] = struct{}{}
-		}
+	newTag, err := selectag.CreateTag(selectedPrefix, newVersion, fmt.Sprintf("%s release", releaseKind), "origin/"+defaultBranch, selectagOptions()...)
+	if err != nil {
+		return fmt.Errorf("failed to create git tag: %w", err)
 	}
+	log.Println("Created git tag:", newTag)
+	fmt.Fprintln(cmd.OutOrStdout(), newTag)
 
-	return slices.Collect(maps.Keys(prefixMap)), nil
-}
-
-func execCmd(stdout io.Writer, stderr io.Writer, name string, args ...string) error {
-	log.Println("Executing command:", name, strings.Join(args, " "))
-	cmd := exec.Command(name, args...)
-	cmd.Stdout = stdout
-	cmd.Stderr = stderr
-	return cmd.Run()
+	return nil
 }
 
 func continued(title string) bool {
@@ -0,0 +1,124 @@
+package selectag
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Commit is a single commit as returned by GitBackend.Log.
+type Commit struct {
+	Hash    string
+	Message string
+}
+
+// GitBackend abstracts the git operations selectag needs, so they can be
+// satisfied either by shelling out to the git binary (the default) or by an
+// in-process implementation such as go-git, for environments without a git
+// binary on PATH (containers, WASM, CI runners) or for unit tests against an
+// in-memory repository.
+type GitBackend interface {
+	// Tags lists every tag in the repository.
+	Tags() ([]string, error)
+	// Log returns the commits reachable from "to" but not from "from",
+	// restricted to path when it is non-empty.
+	Log(from, to, path string) ([]Commit, error)
+	// CreateTag creates an annotated tag named name at ref, with message.
+	CreateTag(name, message, ref string) error
+	// Push pushes ref to remote.
+	Push(remote, ref string) error
+}
+
+// NewCLIGitBackend returns a GitBackend that shells out to the git binary
+// in repoDir. This is selectag's default backend.
+func NewCLIGitBackend(repoDir string) GitBackend {
+	return cliGitBackend{repoDir: repoDir}
+}
+
+type cliGitBackend struct {
+	repoDir string
+}
+
+func (b cliGitBackend) Tags() ([]string, error) {
+	cmd := exec.Command("git", "tag", "-l")
+	cmd.Dir = b.repoDir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list git tags: %w", err)
+	}
+
+	var tags []string
+	for _, t := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if t = strings.TrimSpace(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags, nil
+}
+
+func (b cliGitBackend) Log(from, to, path string) ([]Commit, error) {
+	const recordSeparator = "\x00"
+	const fieldSeparator = "\x01"
+
+	// Use git's own %x00/%x01 pretty-format hex escapes instead of embedding
+	// the raw bytes in the argv string: an argv entry containing a literal
+	// NUL cannot be exec'd on Linux, so the separators must reach git as
+	// ASCII text and let git emit the actual bytes into its output.
+	args := []string{"log", fmt.Sprintf("%s..%s", from, to), "--format=%H%x01%B%x00"}
+	if path != "" {
+		args = append(args, "--", path)
+	}
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = b.repoDir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git log: %w", err)
+	}
+
+	var commits []Commit
+	for _, record := range strings.Split(string(output), recordSeparator) {
+		if record = strings.TrimSpace(record); record == "" {
+			continue
+		}
+		hash, message, _ := strings.Cut(record, fieldSeparator)
+		commits = append(commits, Commit{Hash: hash, Message: strings.TrimSpace(message)})
+	}
+	return commits, nil
+}
+
+func (b cliGitBackend) CreateTag(name, message, ref string) error {
+	cmd := exec.Command("git", "tag", name, "-a", "-m", message, ref)
+	cmd.Dir = b.repoDir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create git tag %s: %w: %s", name, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (b cliGitBackend) Push(remote, ref string) error {
+	cmd := exec.Command("git", "push", remote, ref)
+	cmd.Dir = b.repoDir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to push %s to %s: %w: %s", ref, remote, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// CreateTag creates an annotated tag for prefix at version, pointing at ref
+// (e.g. "origin/main"), using the configured GitBackend, and returns the
+// full tag name that was created.
+func CreateTag(prefix, version, message, ref string, opts ...Option) (string, error) {
+	c := newCfg(opts...)
+	tag := getGitTagFromVersion(prefix, version)
+	if err := c.gitBackend().CreateTag(tag, message, ref); err != nil {
+		return "", err
+	}
+	return tag, nil
+}
+
+// PushTag pushes tag to remote using the configured GitBackend.
+func PushTag(remote, tag string, opts ...Option) error {
+	c := newCfg(opts...)
+	return c.gitBackend().Push(remote, tag)
+}
@@ -0,0 +1,200 @@
+package selectag
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// gitBackends is the set of GitBackend implementations that must agree on
+// every test in this file.
+func gitBackends(t *testing.T, dir string) map[string]GitBackend {
+	t.Helper()
+	goGit, err := NewGoGitBackend(dir)
+	if err != nil {
+		t.Fatalf("NewGoGitBackend: %v", err)
+	}
+	return map[string]GitBackend{
+		"cli":    NewCLIGitBackend(dir),
+		"go-git": goGit,
+	}
+}
+
+func messages(commits []Commit) []string {
+	msgs := make([]string, len(commits))
+	for i, c := range commits {
+		msgs[i] = c.Message
+	}
+	sort.Strings(msgs)
+	return msgs
+}
+
+func TestGitBackendLog(t *testing.T) {
+	dir := initTestRepo(t, []string{"feat: add widget", "fix: handle nil"})
+
+	for name, backend := range gitBackends(t, dir) {
+		t.Run(name, func(t *testing.T) {
+			commits, err := backend.Log("v0.1.0", "HEAD", "")
+			if err != nil {
+				t.Fatalf("Log: %v", err)
+			}
+			got := messages(commits)
+			want := []string{"feat: add widget", "fix: handle nil"}
+			sort.Strings(want)
+			if !equalSlices(got, want) {
+				t.Errorf("Log(...) = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// TestGitBackendLogAcrossMergeCommit guards against an early-stopping walk
+// that silently drops commits reachable only through a sibling parent of a
+// merge: both backends must return the merge commit, the mainline commit,
+// and the feature-branch commit for a from..to range spanning the merge.
+func TestGitBackendLogAcrossMergeCommit(t *testing.T) {
+	dir := initTestRepo(t, nil)
+
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+
+	run("checkout", "-q", "-b", "feature")
+	run("commit", "-q", "--allow-empty", "-m", "feat: feature work")
+	run("checkout", "-q", "main")
+	run("commit", "-q", "--allow-empty", "-m", "fix: mainline work")
+	run("merge", "-q", "--no-ff", "-m", "merge: bring in feature", "feature")
+
+	for name, backend := range gitBackends(t, dir) {
+		t.Run(name, func(t *testing.T) {
+			commits, err := backend.Log("v0.1.0", "HEAD", "")
+			if err != nil {
+				t.Fatalf("Log: %v", err)
+			}
+			got := messages(commits)
+			want := []string{"feat: feature work", "fix: mainline work", "merge: bring in feature"}
+			sort.Strings(want)
+			if !equalSlices(got, want) {
+				t.Errorf("Log(...) = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestGitBackendLogPathFiltered(t *testing.T) {
+	dir := initTestRepo(t, nil)
+
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("MkdirAll: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile %s: %v", name, err)
+		}
+	}
+
+	write("moduleA/file.txt", "a")
+	run("add", "moduleA")
+	run("commit", "-q", "-m", "feat: touch moduleA")
+
+	write("moduleB/file.txt", "b")
+	run("add", "moduleB")
+	run("commit", "-q", "-m", "feat: touch moduleB")
+
+	for name, backend := range gitBackends(t, dir) {
+		t.Run(name, func(t *testing.T) {
+			commits, err := backend.Log("v0.1.0", "HEAD", "moduleA")
+			if err != nil {
+				t.Fatalf("Log: %v", err)
+			}
+			got := messages(commits)
+			want := []string{"feat: touch moduleA"}
+			if !equalSlices(got, want) {
+				t.Errorf("Log(...) = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// TestGitBackendLogPathFilteredSiblingPrefix guards against a path filter
+// that matches on string prefix instead of path segments: a module "foo"
+// must not pull in commits from a sibling "foobar".
+func TestGitBackendLogPathFilteredSiblingPrefix(t *testing.T) {
+	dir := initTestRepo(t, nil)
+
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("MkdirAll: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile %s: %v", name, err)
+		}
+	}
+
+	write("foo/file.txt", "a")
+	run("add", "foo")
+	run("commit", "-q", "-m", "feat: touch foo")
+
+	write("foobar/file.txt", "b")
+	run("add", "foobar")
+	run("commit", "-q", "-m", "feat: touch foobar")
+
+	for name, backend := range gitBackends(t, dir) {
+		t.Run(name, func(t *testing.T) {
+			commits, err := backend.Log("v0.1.0", "HEAD", "foo")
+			if err != nil {
+				t.Fatalf("Log: %v", err)
+			}
+			got := messages(commits)
+			want := []string{"feat: touch foo"}
+			if !equalSlices(got, want) {
+				t.Errorf("Log(...) = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestGitBackendTags(t *testing.T) {
+	dir := initTestRepo(t, nil)
+
+	for name, backend := range gitBackends(t, dir) {
+		t.Run(name, func(t *testing.T) {
+			tags, err := backend.Tags()
+			if err != nil {
+				t.Fatalf("Tags: %v", err)
+			}
+			if !equalSlices(tags, []string{"v0.1.0"}) {
+				t.Errorf("Tags() = %v, want [v0.1.0]", tags)
+			}
+		})
+	}
+}
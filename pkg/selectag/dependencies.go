@@ -0,0 +1,126 @@
+package selectag
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	moduleDirectivePattern = regexp.MustCompile(`(?m)^module\s+(\S+)`)
+	requireLinePattern     = regexp.MustCompile(`^([^\s]+)\s+v\S+`)
+)
+
+// OrderByDependency topologically sorts prefixes so that every sibling
+// module a prefix requires (per that module's go.mod) is tagged before it,
+// preserving the relative input order among modules with no dependency
+// relationship. Prefixes without a readable go.mod are treated as having no
+// dependencies.
+func OrderByDependency(prefixes []string, opts ...Option) ([]string, error) {
+	c := newCfg(opts...)
+
+	modulePaths := make(map[string]string) // prefix -> module path
+	requires := make(map[string][]string)  // prefix -> required module paths
+	for _, p := range prefixes {
+		modPath, reqs, err := readGoMod(c.repoDir, p)
+		if err != nil {
+			continue
+		}
+		modulePaths[p] = modPath
+		requires[p] = reqs
+	}
+
+	prefixByModule := make(map[string]string, len(modulePaths))
+	for p, m := range modulePaths {
+		prefixByModule[m] = p
+	}
+
+	dependsOn := make(map[string][]string)
+	for p, reqs := range requires {
+		for _, r := range reqs {
+			if dep, ok := prefixByModule[r]; ok && dep != p {
+				dependsOn[p] = append(dependsOn[p], dep)
+			}
+		}
+	}
+
+	return topoSort(prefixes, dependsOn)
+}
+
+// readGoMod reads the module path and require targets declared in
+// <repoDir>/<prefix>/go.mod.
+func readGoMod(repoDir, prefix string) (string, []string, error) {
+	path := filepath.Join(repoDir, prefix, "go.mod")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	modMatch := moduleDirectivePattern.FindStringSubmatch(string(data))
+	if modMatch == nil {
+		return "", nil, fmt.Errorf("no module directive found in %s", path)
+	}
+
+	var requires []string
+	inBlock := false
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "require ("):
+			inBlock = true
+		case inBlock && trimmed == ")":
+			inBlock = false
+		case inBlock:
+			if m := requireLinePattern.FindStringSubmatch(trimmed); m != nil {
+				requires = append(requires, m[1])
+			}
+		case strings.HasPrefix(trimmed, "require "):
+			if m := requireLinePattern.FindStringSubmatch(strings.TrimSpace(strings.TrimPrefix(trimmed, "require "))); m != nil {
+				requires = append(requires, m[1])
+			}
+		}
+	}
+
+	return modMatch[1], requires, nil
+}
+
+// topoSort orders items so that every dependency listed in dependsOn appears
+// before the item that requires it. It is a depth-first (post-order)
+// topological sort that otherwise preserves the input order.
+func topoSort(items []string, dependsOn map[string][]string) ([]string, error) {
+	const (
+		unvisited = 0
+		visiting  = 1
+		done      = 2
+	)
+	state := make(map[string]int, len(items))
+	ordered := make([]string, 0, len(items))
+
+	var visit func(item string) error
+	visit = func(item string) error {
+		switch state[item] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("circular module dependency detected involving %q", item)
+		}
+		state[item] = visiting
+		for _, dep := range dependsOn[item] {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[item] = done
+		ordered = append(ordered, item)
+		return nil
+	}
+
+	for _, item := range items {
+		if err := visit(item); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
@@ -0,0 +1,96 @@
+package selectag
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTopoSort(t *testing.T) {
+	t.Run("orders dependencies before dependents", func(t *testing.T) {
+		items := []string{"a", "b", "c"}
+		dependsOn := map[string][]string{"c": {"b"}, "b": {"a"}}
+
+		got, err := topoSort(items, dependsOn)
+		if err != nil {
+			t.Fatalf("topoSort: %v", err)
+		}
+		want := []string{"a", "b", "c"}
+		if !equalSlices(got, want) {
+			t.Errorf("topoSort(...) = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("preserves input order for unrelated items", func(t *testing.T) {
+		items := []string{"x", "y", "z"}
+		got, err := topoSort(items, nil)
+		if err != nil {
+			t.Fatalf("topoSort: %v", err)
+		}
+		if !equalSlices(got, items) {
+			t.Errorf("topoSort(...) = %v, want %v", got, items)
+		}
+	})
+
+	t.Run("detects circular dependency", func(t *testing.T) {
+		items := []string{"a", "b"}
+		dependsOn := map[string][]string{"a": {"b"}, "b": {"a"}}
+		if _, err := topoSort(items, dependsOn); err == nil {
+			t.Fatal("expected an error for a circular dependency, got nil")
+		}
+	})
+}
+
+func equalSlices(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestReadGoMod(t *testing.T) {
+	dir := t.TempDir()
+	moduleDir := filepath.Join(dir, "moduleA")
+	if err := os.MkdirAll(moduleDir, 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+
+	content := `module example.com/moduleA
+
+go 1.21
+
+require (
+	example.com/moduleB v1.2.3
+	example.com/moduleC v0.1.0
+)
+
+require example.com/moduleD v2.0.0
+`
+	if err := os.WriteFile(filepath.Join(moduleDir, "go.mod"), []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	modPath, requires, err := readGoMod(dir, "moduleA")
+	if err != nil {
+		t.Fatalf("readGoMod: %v", err)
+	}
+	if modPath != "example.com/moduleA" {
+		t.Errorf("modPath = %q, want %q", modPath, "example.com/moduleA")
+	}
+
+	want := []string{"example.com/moduleB", "example.com/moduleC", "example.com/moduleD"}
+	if !equalSlices(requires, want) {
+		t.Errorf("requires = %v, want %v", requires, want)
+	}
+}
+
+func TestReadGoModMissingFile(t *testing.T) {
+	if _, _, err := readGoMod(t.TempDir(), "nonexistent"); err == nil {
+		t.Fatal("expected an error for a missing go.mod, got nil")
+	}
+}
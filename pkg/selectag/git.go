@@ -0,0 +1,144 @@
+package selectag
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	version "github.com/hashicorp/go-version"
+)
+
+// Prefixes discovers the unique tag prefixes present in the repository's git
+// tags, e.g. ["", "moduleA", "moduleB/sub"] for a monorepo tagging both the
+// root module and nested ones.
+func Prefixes(opts ...Option) ([]string, error) {
+	c := newCfg(opts...)
+	return collectTagPrefixesFromGit(c)
+}
+
+// CurrentVersion returns the latest released version for prefix, without the
+// "v" (or "<prefix>/v") tag prefix. An empty prefix refers to the root
+// module.
+func CurrentVersion(prefix string, opts ...Option) (string, error) {
+	c := newCfg(opts...)
+	return getCurrentVersion(c, prefix)
+}
+
+// collectTagPrefixesFromGit collects unique tag prefixes from existing git tags
+func collectTagPrefixesFromGit(c *cfg) ([]string, error) {
+	tags, err := c.gitBackend().Tags()
+	if err != nil {
+		// The backend might not be backed by a git repo, or have no tags.
+		// Return empty slice instead of error; callers treat it as "no prefixes found"
+		return []string{}, nil
+	}
+
+	// Regex pattern to match version suffix: /v followed by digits
+	// This captures everything before the version as the prefix
+	versionPattern := regexp.MustCompile(c.tagPattern)
+
+	prefixMap := make(map[string]struct{})
+
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+
+		// Try to extract prefix using regex
+		matches := versionPattern.FindStringSubmatch(tag)
+		if len(matches) > 2 {
+			prefix := matches[2]
+			prefixMap[prefix] = struct{}{}
+		} else if len(matches) > 1 {
+			// Tag is like v1.0.0 with no prefix
+			prefixMap[""] = struct{}{}
+		}
+	}
+
+	return slices.Collect(maps.Keys(prefixMap)), nil
+}
+
+func getCurrentVersion(c *cfg, prefix string) (string, error) {
+	tagPrefix := prefix
+	if tagPrefix == "" {
+		tagPrefix = "v"
+	} else {
+		tagPrefix = tagPrefix + "/v"
+	}
+
+	allTags, err := c.gitBackend().Tags()
+	if err != nil {
+		return "", fmt.Errorf("failed to list git tags: %w", err)
+	}
+
+	var tags []string
+	for _, t := range allTags {
+		if strings.HasPrefix(t, tagPrefix) {
+			tags = append(tags, t)
+		}
+	}
+	if len(tags) == 0 {
+		return "", fmt.Errorf("no tags found with prefix %s", tagPrefix)
+	}
+
+	slices.SortFunc(tags, func(i, j string) int {
+		vi, err1 := version.NewSemver(strings.TrimPrefix(i, tagPrefix))
+		vj, err2 := version.NewSemver(strings.TrimPrefix(j, tagPrefix))
+		if err1 != nil || err2 != nil {
+			return 0
+		}
+		if vi.GreaterThan(vj) {
+			return -1
+		}
+		return 1
+	})
+	return strings.TrimPrefix(tags[0], tagPrefix), nil
+}
+
+// commitMessages returns the full message (subject and body) of every
+// commit reachable from origin/<default branch> but not from fromTag,
+// restricted to path when it is non-empty.
+func commitMessages(c *cfg, fromTag, path string) ([]string, error) {
+	return commitMessagesBetween(c, fromTag, "origin/"+c.defaultBranch, path)
+}
+
+// commitMessagesBetween returns the full message (subject and body) of every
+// commit in the fromRef..toRef range, restricted to path when it is
+// non-empty.
+func commitMessagesBetween(c *cfg, fromRef, toRef, path string) ([]string, error) {
+	commits, err := c.gitBackend().Log(fromRef, toRef, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git log: %w", err)
+	}
+
+	messages := make([]string, len(commits))
+	for i, commit := range commits {
+		messages[i] = commit.Message
+	}
+	return messages, nil
+}
+
+// getGitTagFromVersion builds the full git tag name for a module prefix and
+// version, e.g. ("", "1.2.0") -> "v1.2.0" and ("moduleA", "1.2.0") ->
+// "moduleA/v1.2.0". version may include a prerelease identifier
+// ("1.2.0-rc.1") and/or build metadata ("1.2.0+build.5"); both are valid in
+// a git tag and are preserved verbatim, though build metadata is commonly
+// stripped by tooling that treats it as informational (e.g. Go's module
+// proxy), so a "+build" suffix on a tag may not round-trip through every
+// downstream consumer.
+func getGitTagFromVersion(prefix, version string) string {
+	if prefix == "" {
+		return "v" + version
+	}
+	return prefix + "/v" + version
+}
+
+// GitTag builds the full git tag name for a module prefix and version. It is
+// the exported counterpart of getGitTagFromVersion for callers that need to
+// print or push the tag selectag would create.
+func GitTag(prefix, version string) string {
+	return getGitTagFromVersion(prefix, version)
+}
@@ -0,0 +1,149 @@
+package selectag
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// NewGoGitBackend returns a GitBackend implemented on top of go-git instead
+// of the git binary, for environments without git on PATH or for tests
+// against an in-memory repository.
+func NewGoGitBackend(repoDir string) (GitBackend, error) {
+	repo, err := git.PlainOpen(repoDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository at %s: %w", repoDir, err)
+	}
+	return &goGitBackend{repo: repo}, nil
+}
+
+type goGitBackend struct {
+	repo *git.Repository
+}
+
+func (b *goGitBackend) Tags() ([]string, error) {
+	iter, err := b.repo.Tags()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list git tags: %w", err)
+	}
+	defer iter.Close()
+
+	var tags []string
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		tags = append(tags, ref.Name().Short())
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list git tags: %w", err)
+	}
+	return tags, nil
+}
+
+func (b *goGitBackend) resolve(ref string) (plumbing.Hash, error) {
+	hash, err := b.repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	return *hash, nil
+}
+
+// Log returns the commits reachable from "to" but not from "from". A plain
+// iterate-until-from-is-seen walk is not equivalent to git's from..to range
+// once history has a merge commit: a commit reachable only through a
+// sibling parent of a merge can be skipped if the walk happens to hit
+// "from" along a different path first. Instead, collect every commit
+// reachable from "from" and exclude that whole set from a full walk of
+// "to"'s ancestry.
+func (b *goGitBackend) Log(from, to, path string) ([]Commit, error) {
+	fromHash, err := b.resolve(from)
+	if err != nil {
+		return nil, err
+	}
+	toHash, err := b.resolve(to)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded, err := b.ancestorSet(fromHash)
+	if err != nil {
+		return nil, err
+	}
+	excluded[fromHash] = struct{}{}
+
+	logOpts := &git.LogOptions{From: toHash}
+	if path != "" {
+		// Match git's own pathspec semantics: "foo" selects the path "foo"
+		// and everything under "foo/", but not a sibling like "foobar".
+		logOpts.PathFilter = func(p string) bool { return p == path || strings.HasPrefix(p, path+"/") }
+	}
+	iter, err := b.repo.Log(logOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git log: %w", err)
+	}
+	defer iter.Close()
+
+	var commits []Commit
+	err = iter.ForEach(func(c *object.Commit) error {
+		if _, ok := excluded[c.Hash]; ok {
+			return nil
+		}
+		commits = append(commits, Commit{Hash: c.Hash.String(), Message: strings.TrimSpace(c.Message)})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git log: %w", err)
+	}
+	return commits, nil
+}
+
+// ancestorSet returns every commit hash reachable from hash, including hash
+// itself.
+func (b *goGitBackend) ancestorSet(hash plumbing.Hash) (map[plumbing.Hash]struct{}, error) {
+	iter, err := b.repo.Log(&git.LogOptions{From: hash})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git log: %w", err)
+	}
+	defer iter.Close()
+
+	seen := make(map[plumbing.Hash]struct{})
+	err = iter.ForEach(func(c *object.Commit) error {
+		seen[c.Hash] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git log: %w", err)
+	}
+	return seen, nil
+}
+
+func (b *goGitBackend) CreateTag(name, message, ref string) error {
+	hash, err := b.resolve(ref)
+	if err != nil {
+		return err
+	}
+	_, err = b.repo.CreateTag(name, hash, &git.CreateTagOptions{
+		Message: message,
+		Tagger:  &object.Signature{Name: "selectag", When: time.Now()},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create git tag %s: %w", name, err)
+	}
+	return nil
+}
+
+func (b *goGitBackend) Push(remote, ref string) error {
+	refSpec := config.RefSpec(fmt.Sprintf("refs/tags/%s:refs/tags/%s", ref, ref))
+	err := b.repo.Push(&git.PushOptions{
+		RemoteName: remote,
+		RefSpecs:   []config.RefSpec{refSpec},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to push %s to %s: %w", ref, remote, err)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package selectag
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// sectionOrder defines both the known release-notes sections and the order
+// they're rendered in. Commits whose type has no category land in "Other";
+// custom titles from WithCategories are placed as ComposeNotes describes.
+var sectionOrder = []string{
+	"Breaking Changes",
+	"Features",
+	"Bug Fixes",
+	"Performance",
+	"Refactoring",
+	"Documentation",
+	"Other",
+}
+
+// defaultCategories maps a conventional-commit type to the section its
+// commits are grouped under. Override it per call with WithCategories.
+var defaultCategories = map[string]string{
+	"feat":     "Features",
+	"fix":      "Bug Fixes",
+	"perf":     "Performance",
+	"refactor": "Refactoring",
+	"docs":     "Documentation",
+}
+
+var issueRefPattern = regexp.MustCompile(`(?i)(?:closes?|fixes?|updates?|see) #(\d+)`)
+
+// CommitEntry is a single commit as rendered in a release-notes section.
+type CommitEntry struct {
+	Subject string
+	Issues  []int
+}
+
+// NotesSection groups commits under one of sectionOrder's titles or a custom
+// title introduced via WithCategories.
+type NotesSection struct {
+	Title   string
+	Commits []CommitEntry
+}
+
+// ReleaseNotes is a categorized changelog produced by ComposeNotes. Sections
+// are ordered as ComposeNotes describes and only included when they have
+// commits.
+type ReleaseNotes struct {
+	Sections []NotesSection
+}
+
+// Markdown renders notes as the built-in markdown format: one "## <Section>"
+// heading per populated section, followed by a bullet list of commits with
+// any referenced issues appended in parentheses.
+func (n ReleaseNotes) Markdown() string {
+	var b strings.Builder
+	for _, s := range n.Sections {
+		fmt.Fprintf(&b, "## %s\n\n", s.Title)
+		for _, c := range s.Commits {
+			line := "- " + c.Subject
+			if len(c.Issues) > 0 {
+				refs := make([]string, len(c.Issues))
+				for i, issue := range c.Issues {
+					refs[i] = fmt.Sprintf("#%d", issue)
+				}
+				line += " (" + strings.Join(refs, ", ") + ")"
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// ComposeNotes groups the commits between fromRef and toRef (path-filtered
+// to prefix when it is non-empty) into a categorized ReleaseNotes, using
+// conventional-commit types and a "BREAKING CHANGE:"/"!" check to assign
+// each commit to a section. Sections are rendered in sectionOrder; custom
+// titles introduced via WithCategories are rendered alphabetically between
+// "Documentation" and "Other" so they never go missing from the changelog.
+func ComposeNotes(fromRef, toRef, prefix string, opts ...Option) (ReleaseNotes, error) {
+	c := newCfg(opts...)
+	messages, err := commitMessagesBetween(c, fromRef, toRef, prefix)
+	if err != nil {
+		return ReleaseNotes{}, fmt.Errorf("failed to inspect commits between %s and %s: %w", fromRef, toRef, err)
+	}
+
+	byTitle := make(map[string][]CommitEntry)
+	for _, msg := range messages {
+		header, _, _ := strings.Cut(msg, "\n")
+		title := categorizeCommit(msg, header, c.categories)
+		byTitle[title] = append(byTitle[title], CommitEntry{
+			Subject: header,
+			Issues:  extractIssues(msg),
+		})
+	}
+
+	rendered := make(map[string]bool, len(sectionOrder))
+	var notes ReleaseNotes
+	for _, title := range sectionOrder {
+		if title == "Other" {
+			continue
+		}
+		if commits, ok := byTitle[title]; ok {
+			notes.Sections = append(notes.Sections, NotesSection{Title: title, Commits: commits})
+			rendered[title] = true
+		}
+	}
+
+	var custom []string
+	for title := range byTitle {
+		if title == "Other" || rendered[title] {
+			continue
+		}
+		custom = append(custom, title)
+	}
+	slices.Sort(custom)
+	for _, title := range custom {
+		notes.Sections = append(notes.Sections, NotesSection{Title: title, Commits: byTitle[title]})
+	}
+
+	if commits, ok := byTitle["Other"]; ok {
+		notes.Sections = append(notes.Sections, NotesSection{Title: "Other", Commits: commits})
+	}
+	return notes, nil
+}
+
+func categorizeCommit(msg, header string, categories map[string]string) string {
+	if breakingFooterPattern.MatchString(msg) {
+		return "Breaking Changes"
+	}
+	match := conventionalHeaderPattern.FindStringSubmatch(header)
+	if match == nil {
+		return "Other"
+	}
+	if match[3] == "!" {
+		return "Breaking Changes"
+	}
+	if title, ok := categories[match[1]]; ok {
+		return title
+	}
+	return "Other"
+}
+
+func extractIssues(msg string) []int {
+	matches := issueRefPattern.FindAllStringSubmatch(msg, -1)
+	if matches == nil {
+		return nil
+	}
+	issues := make([]int, 0, len(matches))
+	for _, m := range matches {
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			continue
+		}
+		issues = append(issues, n)
+	}
+	return issues
+}
@@ -0,0 +1,143 @@
+package selectag
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func initTestRepo(t *testing.T, commitMessages []string) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+
+	run("init", "-q", "-b", "main")
+	run("config", "user.email", "test@example.com")
+	run("config", "user.name", "test")
+	run("commit", "-q", "--allow-empty", "-m", "feat: initial")
+	run("tag", "v0.1.0")
+	for _, msg := range commitMessages {
+		run("commit", "-q", "--allow-empty", "-m", msg)
+	}
+	return dir
+}
+
+func TestExtractIssues(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		want []int
+	}{
+		{"no refs", "feat: add widget", nil},
+		{"closes", "fix: handle nil\n\nCloses #42", []int{42}},
+		{"multiple refs", "fix: handle nil\n\nFixes #1\nSee #2", []int{1, 2}},
+		{"case insensitive", "fix: handle nil\n\nUPDATES #7", []int{7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := extractIssues(tt.msg)
+			if !equalInts(got, tt.want) {
+				t.Errorf("extractIssues(%q) = %v, want %v", tt.msg, got, tt.want)
+			}
+		})
+	}
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCategorizeCommit(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		want string
+	}{
+		{"feat", "feat: add widget", "Features"},
+		{"fix", "fix: a bug", "Bug Fixes"},
+		{"breaking bang", "feat!: drop support", "Breaking Changes"},
+		{"breaking footer", "feat: add widget\n\nBREAKING CHANGE: removed old API", "Breaking Changes"},
+		{"unknown type", "chore: bump deps", "Other"},
+		{"no header", "some commit with no conventional prefix", "Other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header, _, _ := strings.Cut(tt.msg, "\n")
+			if got := categorizeCommit(tt.msg, header, defaultCategories); got != tt.want {
+				t.Errorf("categorizeCommit(%q) = %q, want %q", tt.msg, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComposeNotesRendersCustomCategory(t *testing.T) {
+	dir := initTestRepo(t, []string{
+		"feat: add widget",
+		"security: patch CVE\n\nCloses #9",
+		"chore: bump deps",
+	})
+
+	notes, err := ComposeNotes("v0.1.0", "HEAD", "", WithRepoDir(dir), WithCategories(map[string]string{"security": "Security"}))
+	if err != nil {
+		t.Fatalf("ComposeNotes: %v", err)
+	}
+
+	got := notes.Markdown()
+	wantOrder := []string{"## Features", "## Security", "## Other"}
+	lastIdx := -1
+	for _, heading := range wantOrder {
+		idx := strings.Index(got, heading)
+		if idx < 0 {
+			t.Fatalf("Markdown() missing heading %q in:\n%s", heading, got)
+		}
+		if idx < lastIdx {
+			t.Fatalf("Markdown() rendered %q out of order in:\n%s", heading, got)
+		}
+		lastIdx = idx
+	}
+	if !strings.Contains(got, "patch CVE (#9)") {
+		t.Errorf("Markdown() missing issue ref for custom-category commit in:\n%s", got)
+	}
+}
+
+func TestReleaseNotesMarkdownRendersCustomCategoriesBeforeOther(t *testing.T) {
+	notes := ReleaseNotes{
+		Sections: []NotesSection{
+			{Title: "Features", Commits: []CommitEntry{{Subject: "feat: add widget"}}},
+			{Title: "Security", Commits: []CommitEntry{{Subject: "security: patch CVE", Issues: []int{9}}}},
+			{Title: "Other", Commits: []CommitEntry{{Subject: "chore: bump deps"}}},
+		},
+	}
+
+	got := notes.Markdown()
+	wantOrder := []string{"## Features", "## Security", "## Other"}
+	lastIdx := -1
+	for _, heading := range wantOrder {
+		idx := strings.Index(got, heading)
+		if idx < 0 {
+			t.Fatalf("Markdown() missing heading %q in:\n%s", heading, got)
+		}
+		if idx < lastIdx {
+			t.Fatalf("Markdown() rendered %q out of order in:\n%s", heading, got)
+		}
+		lastIdx = idx
+	}
+}
@@ -0,0 +1,141 @@
+package selectag
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// defaultTagPattern matches the version suffix convention used by
+// collectTagPrefixesFromGit: "/v" followed by digits, or a bare "v" tag.
+const defaultTagPattern = `^((.*)/v\d+|v\d+)`
+
+// cfg holds the resolved settings shared by every exported function in this
+// package. It is built from Option values via newCfg.
+type cfg struct {
+	repoDir            string
+	defaultBranch      string
+	tagPattern         string
+	pathBased          bool
+	categories         map[string]string
+	gitBackendImpl     GitBackend
+	releaseBackendImpl ReleaseBackend
+}
+
+// Option configures how selectag interacts with a repository. Pass Options
+// to NextVersion, CurrentVersion, Prefixes, and Verify to customize their
+// behavior without shelling out to the selectag binary.
+type Option func(*cfg)
+
+// WithRepoDir sets the directory in which git commands are run. Defaults to
+// the current working directory.
+func WithRepoDir(dir string) Option {
+	return func(c *cfg) {
+		c.repoDir = dir
+	}
+}
+
+// WithDefaultBranch sets the branch that new tags and comparisons are made
+// against (e.g. "main"). Defaults to the branch origin/HEAD points at, or
+// "main" if that cannot be determined.
+func WithDefaultBranch(branch string) Option {
+	return func(c *cfg) {
+		c.defaultBranch = branch
+	}
+}
+
+// WithTagPattern overrides the regular expression Prefixes uses to recognize
+// version tags and extract their prefix (the pattern's second capture
+// group). Defaults to matching "<prefix>/vX.Y.Z" and "vX.Y.Z".
+func WithTagPattern(pattern string) Option {
+	return func(c *cfg) {
+		c.tagPattern = pattern
+	}
+}
+
+// WithPathBased controls whether Verify restricts its git log comparison to
+// commits touching the module's prefix path. Defaults to true.
+func WithPathBased(pathBased bool) Option {
+	return func(c *cfg) {
+		c.pathBased = pathBased
+	}
+}
+
+// WithCategories overrides which release-notes section a conventional-commit
+// type is grouped under, keyed by type (e.g. "feat", "fix"). Types not
+// present in the map fall back to defaultCategories, then to "Other".
+func WithCategories(categories map[string]string) Option {
+	return func(c *cfg) {
+		merged := make(map[string]string, len(defaultCategories)+len(categories))
+		for k, v := range defaultCategories {
+			merged[k] = v
+		}
+		for k, v := range categories {
+			merged[k] = v
+		}
+		c.categories = merged
+	}
+}
+
+// WithGitBackend overrides how selectag reads and writes git state. Defaults
+// to NewCLIGitBackend(repoDir); pass NewGoGitBackend(repoDir) to run without
+// a git binary on PATH.
+func WithGitBackend(backend GitBackend) Option {
+	return func(c *cfg) {
+		c.gitBackendImpl = backend
+	}
+}
+
+// WithReleaseBackend overrides how selectag creates GitHub releases.
+// Defaults to NewGHReleaseBackend(); pass NewGoGithubReleaseBackend(...) to
+// call the GitHub API directly instead of shelling out to gh, or
+// NewNoopReleaseBackend() to skip the release step.
+func WithReleaseBackend(backend ReleaseBackend) Option {
+	return func(c *cfg) {
+		c.releaseBackendImpl = backend
+	}
+}
+
+func (c *cfg) gitBackend() GitBackend {
+	if c.gitBackendImpl == nil {
+		c.gitBackendImpl = NewCLIGitBackend(c.repoDir)
+	}
+	return c.gitBackendImpl
+}
+
+func (c *cfg) releaseBackend() ReleaseBackend {
+	if c.releaseBackendImpl == nil {
+		c.releaseBackendImpl = NewGHReleaseBackend()
+	}
+	return c.releaseBackendImpl
+}
+
+func newCfg(opts ...Option) *cfg {
+	c := &cfg{
+		repoDir:    ".",
+		tagPattern: defaultTagPattern,
+		pathBased:  true,
+		categories: defaultCategories,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.defaultBranch == "" {
+		c.defaultBranch = detectDefaultBranch(c.repoDir)
+	}
+	return c
+}
+
+// detectDefaultBranch mirrors the detection NewRootCommand performs: it asks
+// git what origin/HEAD points at in repoDir, falling back to "main". It runs
+// after WithRepoDir/WithDefaultBranch are applied, so it respects the
+// caller's chosen directory and is skipped entirely when a default branch
+// was supplied explicitly.
+func detectDefaultBranch(repoDir string) string {
+	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "origin/HEAD")
+	cmd.Dir = repoDir
+	out, err := cmd.Output()
+	if err != nil {
+		return "main"
+	}
+	return strings.TrimSpace(strings.TrimPrefix(string(out), "origin/"))
+}
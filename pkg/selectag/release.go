@@ -0,0 +1,92 @@
+package selectag
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+	"golang.org/x/oauth2"
+)
+
+// ReleaseBackend abstracts creating a GitHub release for a tag, so the step
+// can be swapped out (e.g. for go-github) or skipped entirely instead of
+// always shelling out to the gh CLI.
+type ReleaseBackend interface {
+	// CreateRelease creates a release for tag, with notes read from
+	// notesPath, as a draft when draft is true.
+	CreateRelease(tag, notesPath string, draft bool) error
+}
+
+// NewGHReleaseBackend returns a ReleaseBackend that shells out to the gh
+// CLI. This is selectag's default release backend.
+func NewGHReleaseBackend() ReleaseBackend {
+	return ghCLIReleaseBackend{}
+}
+
+type ghCLIReleaseBackend struct{}
+
+func (ghCLIReleaseBackend) CreateRelease(tag, notesPath string, draft bool) error {
+	args := []string{"release", "create", tag, "--notes-file", notesPath, "--fail-on-no-commits"}
+	if draft {
+		args = append(args, "--draft")
+	}
+	if out, err := exec.Command("gh", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create GitHub release for %s: %w: %s", tag, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// NewGoGithubReleaseBackend returns a ReleaseBackend that talks to the
+// GitHub API directly via go-github, for environments without the gh CLI
+// installed. owner/repo identify the GitHub repository (e.g. "wreulicke",
+// "selectag") and token is a GitHub personal access token or GITHUB_TOKEN.
+func NewGoGithubReleaseBackend(owner, repo, token string) ReleaseBackend {
+	return goGithubReleaseBackend{owner: owner, repo: repo, token: token}
+}
+
+type goGithubReleaseBackend struct {
+	owner string
+	repo  string
+	token string
+}
+
+func (b goGithubReleaseBackend) CreateRelease(tag, notesPath string, draft bool) error {
+	notes, err := os.ReadFile(notesPath)
+	if err != nil {
+		return fmt.Errorf("failed to read release notes %s: %w", notesPath, err)
+	}
+
+	ctx := context.Background()
+	client := github.NewClient(oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: b.token})))
+
+	body := string(notes)
+	_, _, err = client.Repositories.CreateRelease(ctx, b.owner, b.repo, &github.RepositoryRelease{
+		TagName: &tag,
+		Body:    &body,
+		Draft:   &draft,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub release for %s: %w", tag, err)
+	}
+	return nil
+}
+
+// NewNoopReleaseBackend returns a ReleaseBackend that does nothing, so the
+// GitHub release step can be skipped entirely (e.g. when only tagging).
+func NewNoopReleaseBackend() ReleaseBackend {
+	return noopReleaseBackend{}
+}
+
+type noopReleaseBackend struct{}
+
+func (noopReleaseBackend) CreateRelease(tag, notesPath string, draft bool) error { return nil }
+
+// CreateRelease creates a GitHub release for tag using the configured
+// ReleaseBackend (the gh CLI by default), with notes read from notesPath.
+func CreateRelease(tag, notesPath string, draft bool, opts ...Option) error {
+	c := newCfg(opts...)
+	return c.releaseBackend().CreateRelease(tag, notesPath, draft)
+}
@@ -0,0 +1,5 @@
+// Package selectag implements the tag-selection, version-bumping,
+// prefix-discovery, and verification logic behind the selectag CLI so it can
+// be called directly from magefiles, GitHub Actions, or other release
+// automation without shelling out to the binary.
+package selectag
@@ -0,0 +1,63 @@
+package selectag
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// VerifyResult reports how many unreleased commits exist for a module
+// prefix, as computed by Verify.
+type VerifyResult struct {
+	Prefix     string
+	NumChanges int
+}
+
+// Verify checks, for each of prefixes, how many commits on the default
+// branch are not yet covered by that module's latest tag. It is the library
+// equivalent of the `selectag verify` subcommand.
+func Verify(prefixes []string, opts ...Option) ([]VerifyResult, error) {
+	c := newCfg(opts...)
+
+	checkForUpdates := func(prefix string, ver string) (int, error) {
+		tag := getGitTagFromVersion(prefix, ver)
+
+		path := ""
+		if c.pathBased {
+			path = prefix
+		}
+		commits, err := c.gitBackend().Log(tag, "origin/"+c.defaultBranch, path)
+		if err != nil {
+			return 0, fmt.Errorf("failed to check git log: %w", err)
+		}
+		return len(commits), nil
+	}
+
+	var lock sync.Mutex
+	var results []VerifyResult
+	var eg errgroup.Group
+	eg.SetLimit(runtime.NumCPU() * 8)
+	for _, p := range prefixes {
+		eg.Go(func() error {
+			current, err := getCurrentVersion(c, p)
+			if err != nil {
+				return fmt.Errorf("failed to get current version for prefix '%s': %w", p, err)
+			}
+			numChanges, err := checkForUpdates(p, current)
+			if err != nil {
+				return fmt.Errorf("failed to check for updates for prefix '%s': %w", p, err)
+			}
+			lock.Lock()
+			defer lock.Unlock()
+			results = append(results, VerifyResult{Prefix: p, NumChanges: numChanges})
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,252 @@
+package selectag
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	version "github.com/hashicorp/go-version"
+)
+
+// Bump identifies which part of a semantic version to increment.
+type Bump string
+
+const (
+	// BumpNone indicates that no commits warranting a release were found.
+	BumpNone  Bump = ""
+	BumpPatch Bump = "patch"
+	BumpMinor Bump = "minor"
+	BumpMajor Bump = "major"
+)
+
+// NextVersion computes the version that a bump of kind would produce for
+// prefix's current release, without creating or touching any tag. The
+// returned string has no "v" (or "<prefix>/v") prefix, matching
+// CurrentVersion.
+func NextVersion(prefix string, bump Bump, opts ...Option) (string, error) {
+	c := newCfg(opts...)
+	current, err := getCurrentVersion(c, prefix)
+	if err != nil {
+		return "", fmt.Errorf("failed to get current version: %w", err)
+	}
+	return bumpVersion(current, bump)
+}
+
+// SuggestBump inspects the conventional-commit messages between prefix's
+// current tag and the default branch (path-filtered to prefix when it is
+// non-empty) and returns the bump they imply: BumpMajor if any commit has a
+// "!" after its type/scope or a "BREAKING CHANGE:" footer, BumpMinor if any
+// commit is a "feat:", BumpPatch if any commit is a "fix:", "perf:", or
+// "refactor:", and BumpNone if nothing in the range matches.
+func SuggestBump(prefix string, opts ...Option) (Bump, error) {
+	c := newCfg(opts...)
+	current, err := getCurrentVersion(c, prefix)
+	if err != nil {
+		return BumpNone, fmt.Errorf("failed to get current version: %w", err)
+	}
+	tag := getGitTagFromVersion(prefix, current)
+
+	messages, err := commitMessages(c, tag, prefix)
+	if err != nil {
+		return BumpNone, fmt.Errorf("failed to inspect commits since %s: %w", tag, err)
+	}
+	return classifyBump(messages), nil
+}
+
+var (
+	conventionalHeaderPattern = regexp.MustCompile(`^(\w+)(\([^)]*\))?(!)?:\s`)
+	breakingFooterPattern     = regexp.MustCompile(`(?m)^BREAKING CHANGE:`)
+)
+
+// classifyBump returns the strongest bump implied by messages, where
+// messages are full commit messages (subject plus body).
+func classifyBump(messages []string) Bump {
+	rank := func(b Bump) int {
+		switch b {
+		case BumpMajor:
+			return 3
+		case BumpMinor:
+			return 2
+		case BumpPatch:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	best := BumpNone
+	for _, msg := range messages {
+		bump := classifyMessage(msg)
+		if rank(bump) > rank(best) {
+			best = bump
+		}
+	}
+	return best
+}
+
+func classifyMessage(msg string) Bump {
+	if breakingFooterPattern.MatchString(msg) {
+		return BumpMajor
+	}
+
+	header, _, _ := strings.Cut(msg, "\n")
+	match := conventionalHeaderPattern.FindStringSubmatch(header)
+	if match == nil {
+		return BumpNone
+	}
+	if match[3] == "!" {
+		return BumpMajor
+	}
+
+	switch match[1] {
+	case "feat":
+		return BumpMinor
+	case "fix", "perf", "refactor":
+		return BumpPatch
+	default:
+		return BumpNone
+	}
+}
+
+func bumpVersion(current string, bump Bump) (string, error) {
+	v, err := version.NewSemver(current)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse current version: %w", err)
+	}
+	segments := v.Segments()
+
+	switch bump {
+	case BumpMajor:
+		return fmt.Sprintf("%d.0.0", segments[0]+1), nil
+	case BumpMinor:
+		return fmt.Sprintf("%d.%d.0", segments[0], segments[1]+1), nil
+	case BumpPatch:
+		return fmt.Sprintf("%d.%d.%d", segments[0], segments[1], segments[2]+1), nil
+	default:
+		return "", fmt.Errorf("unknown bump kind: %s", bump)
+	}
+}
+
+// NextPrereleaseVersion computes the prerelease version that label would
+// produce for prefix's current release, e.g. NextPrereleaseVersion(prefix,
+// BumpMinor, "rc") returns "1.3.0-rc.1" when prefix is currently at "1.2.0".
+// If the current version already carries a prerelease under the same label,
+// bump is ignored and the prerelease's numeric suffix is incremented
+// instead, e.g. "1.3.0-rc.1" -> "1.3.0-rc.2".
+func NextPrereleaseVersion(prefix string, bump Bump, label string, opts ...Option) (string, error) {
+	c := newCfg(opts...)
+	current, err := getCurrentVersion(c, prefix)
+	if err != nil {
+		return "", fmt.Errorf("failed to get current version: %w", err)
+	}
+
+	v, err := version.NewSemver(current)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse current version: %w", err)
+	}
+
+	if existingLabel, num, ok := parsePrerelease(v.Prerelease()); ok && existingLabel == label {
+		return prereleaseVersion(baseVersion(v), label, num+1), nil
+	}
+
+	base, err := bumpVersion(current, bump)
+	if err != nil {
+		return "", err
+	}
+	return prereleaseVersion(base, label, 1), nil
+}
+
+// BumpPrerelease increments the numeric suffix of prefix's current
+// prerelease version, e.g. "1.3.0-rc.1" -> "1.3.0-rc.2". It returns an error
+// if the current version carries no prerelease identifier.
+func BumpPrerelease(prefix string, opts ...Option) (string, error) {
+	c := newCfg(opts...)
+	current, err := getCurrentVersion(c, prefix)
+	if err != nil {
+		return "", fmt.Errorf("failed to get current version: %w", err)
+	}
+
+	v, err := version.NewSemver(current)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse current version: %w", err)
+	}
+
+	label, num, ok := parsePrerelease(v.Prerelease())
+	if !ok {
+		return "", fmt.Errorf("current version %s has no prerelease identifier to bump", current)
+	}
+	return prereleaseVersion(baseVersion(v), label, num+1), nil
+}
+
+// PromoteToRelease strips prefix's current prerelease and build-metadata
+// identifiers, e.g. "1.3.0-rc.1+build.5" -> "1.3.0". It returns an error if
+// the current version carries no prerelease identifier.
+func PromoteToRelease(prefix string, opts ...Option) (string, error) {
+	c := newCfg(opts...)
+	current, err := getCurrentVersion(c, prefix)
+	if err != nil {
+		return "", fmt.Errorf("failed to get current version: %w", err)
+	}
+
+	v, err := version.NewSemver(current)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse current version: %w", err)
+	}
+	if v.Prerelease() == "" {
+		return "", fmt.Errorf("current version %s has no prerelease identifier to promote", current)
+	}
+	return baseVersion(v), nil
+}
+
+func baseVersion(v *version.Version) string {
+	segments := v.Segments()
+	return fmt.Sprintf("%d.%d.%d", segments[0], segments[1], segments[2])
+}
+
+var prereleaseNumberPattern = regexp.MustCompile(`^([0-9A-Za-z-]+)\.(\d+)$`)
+
+// parsePrerelease splits a go-version Prerelease() value such as "rc.1" into
+// its label ("rc") and numeric suffix (1). ok is false only when prerelease
+// is empty; a prerelease with no numeric suffix (e.g. "rc") is returned with
+// num 0 so callers can still start bumping it from 1.
+func parsePrerelease(prerelease string) (label string, num int, ok bool) {
+	if prerelease == "" {
+		return "", 0, false
+	}
+	match := prereleaseNumberPattern.FindStringSubmatch(prerelease)
+	if match == nil {
+		return prerelease, 0, true
+	}
+	n, err := strconv.Atoi(match[2])
+	if err != nil {
+		return prerelease, 0, true
+	}
+	return match[1], n, true
+}
+
+func prereleaseVersion(base, label string, num int) string {
+	return fmt.Sprintf("%s-%s.%d", base, label, num)
+}
+
+// ValidateVersion checks that s is a valid semantic version, with or without
+// a leading "v".
+func ValidateVersion(s string) error {
+	if s == "" {
+		return fmt.Errorf("version cannot be empty")
+	}
+
+	// Add 'v' prefix if not present for validation
+	versionStr := s
+	if !strings.HasPrefix(versionStr, "v") {
+		versionStr = "v" + versionStr
+	}
+
+	// Parse and validate using go-version
+	_, err := version.NewSemver(versionStr)
+	if err != nil {
+		return fmt.Errorf("invalid version format: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package selectag
+
+import (
+	"testing"
+
+	version "github.com/hashicorp/go-version"
+)
+
+func TestClassifyMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		want Bump
+	}{
+		{"feat", "feat: add widget", BumpMinor},
+		{"fix", "fix: handle nil pointer", BumpPatch},
+		{"perf", "perf: speed up parsing", BumpPatch},
+		{"refactor", "refactor: extract helper", BumpPatch},
+		{"docs", "docs: update README", BumpNone},
+		{"scoped feat", "feat(api): add endpoint", BumpMinor},
+		{"breaking bang", "feat!: drop legacy flag", BumpMajor},
+		{"scoped breaking bang", "fix(core)!: change signature", BumpMajor},
+		{"breaking footer", "feat: add widget\n\nBREAKING CHANGE: removes old API", BumpMajor},
+		{"no conventional header", "update stuff", BumpNone},
+		{"unknown type", "chore: bump deps", BumpNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyMessage(tt.msg); got != tt.want {
+				t.Errorf("classifyMessage(%q) = %v, want %v", tt.msg, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifyBump(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages []string
+		want     Bump
+	}{
+		{"empty", nil, BumpNone},
+		{"patch only", []string{"fix: a bug"}, BumpPatch},
+		{"minor beats patch", []string{"fix: a bug", "feat: a feature"}, BumpMinor},
+		{"major beats everything", []string{"fix: a bug", "feat: a feature", "feat!: breaking"}, BumpMajor},
+		{"all none", []string{"docs: typo", "chore: cleanup"}, BumpNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyBump(tt.messages); got != tt.want {
+				t.Errorf("classifyBump(%v) = %v, want %v", tt.messages, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePrerelease(t *testing.T) {
+	tests := []struct {
+		name       string
+		prerelease string
+		wantLabel  string
+		wantNum    int
+		wantOK     bool
+	}{
+		{"empty", "", "", 0, false},
+		{"labeled number", "rc.1", "rc", 1, true},
+		{"larger number", "beta.12", "beta", 12, true},
+		{"no number", "rc", "rc", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			label, num, ok := parsePrerelease(tt.prerelease)
+			if label != tt.wantLabel || num != tt.wantNum || ok != tt.wantOK {
+				t.Errorf("parsePrerelease(%q) = (%q, %d, %v), want (%q, %d, %v)", tt.prerelease, label, num, ok, tt.wantLabel, tt.wantNum, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestPrereleaseVersion(t *testing.T) {
+	if got := prereleaseVersion("1.3.0", "rc", 1); got != "1.3.0-rc.1" {
+		t.Errorf("prereleaseVersion(...) = %q, want %q", got, "1.3.0-rc.1")
+	}
+}
+
+func TestBaseVersion(t *testing.T) {
+	v, err := version.NewSemver("1.3.0-rc.1+build.5")
+	if err != nil {
+		t.Fatalf("NewSemver: %v", err)
+	}
+	if got := baseVersion(v); got != "1.3.0" {
+		t.Errorf("baseVersion(...) = %q, want %q", got, "1.3.0")
+	}
+}